@@ -0,0 +1,48 @@
+package gamestate
+
+import (
+	"context"
+	"errors"
+
+	"pkg.world.dev/world-engine/cardinal/types"
+	"pkg.world.dev/world-engine/cardinal/types/txpool"
+)
+
+// ErrKeyNotFound is returned by PrimitiveStorage implementations when a Get-style call targets a
+// key that was never written. Callers should check for this with eris.Is against eris.Cause
+// instead of reaching for a backend-specific sentinel like redis.Nil, so EntityCommandBuffer's
+// recovery and atomicity contract can be exercised against any backend, including the in-memory
+// one used in unit tests.
+var ErrKeyNotFound = errors.New("gamestate: key not found")
+
+// PrimitiveStorage is the minimal key/value boundary EntityCommandBuffer needs from a storage
+// backend: scalar counters for tick bookkeeping, byte blobs for encoded state, key enumeration for
+// checkpointing, and a pipe abstraction so a batch of writes commits atomically.
+type PrimitiveStorage[K comparable] interface {
+	GetUInt64(ctx context.Context, key K) (uint64, error)
+	SetUInt64(ctx context.Context, key K, value uint64) error
+	Incr(ctx context.Context, key K) error
+	GetBytes(ctx context.Context, key K) ([]byte, error)
+	Set(ctx context.Context, key K, value []byte) error
+	Delete(ctx context.Context, key K) error
+	Keys(ctx context.Context, pattern K) ([]K, error)
+
+	// StartTransaction opens a pipe of buffered commands that all apply atomically once
+	// EndTransaction is called on the PrimitiveStorage it returns.
+	StartTransaction(ctx context.Context) (PrimitiveStorage[K], error)
+	EndTransaction(ctx context.Context) error
+}
+
+// TickStorage is the tick-lifecycle contract EntityCommandBuffer implements on top of a
+// PrimitiveStorage backend: starting a tick, finalizing it, recovering from a crash mid-tick, and
+// reading back the last start/end tick numbers.
+type TickStorage interface {
+	GetTickNumbers() (start, end uint64, err error)
+	StartNextTick(ctx context.Context, txs []types.Message, pool *txpool.TxPool) error
+	FinalizeTick(ctx context.Context) error
+	Recover(txs []types.Message) (*txpool.TxPool, error)
+}
+
+func storageStartTickKey() string          { return "tick_start" }
+func storageEndTickKey() string            { return "tick_end" }
+func storagePendingTransactionKey() string { return "pending_transaction" }
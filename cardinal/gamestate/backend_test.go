@@ -0,0 +1,41 @@
+package gamestate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryBackend_GetBytesNotFound(t *testing.T) {
+	ctx := context.Background()
+	backend := newMemoryBackend()
+
+	_, err := backend.GetBytes(ctx, "missing")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestMemoryBackend_TransactionIsAtomic(t *testing.T) {
+	ctx := context.Background()
+	backend := newMemoryBackend()
+
+	pipe, err := backend.StartTransaction(ctx)
+	require.NoError(t, err)
+	require.NoError(t, pipe.Set(ctx, "a", []byte("1")))
+	require.NoError(t, pipe.Incr(ctx, "tick"))
+
+	// Writes made on the pipe must not be visible on the backend until EndTransaction commits.
+	_, err = backend.GetBytes(ctx, "a")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	require.NoError(t, pipe.EndTransaction(ctx))
+
+	value, err := backend.GetBytes(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("1"), value)
+
+	tick, err := backend.GetUInt64(ctx, "tick")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), tick)
+}
@@ -0,0 +1,147 @@
+package gamestate
+
+import (
+	"context"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/rotisserie/eris"
+	bolt "go.etcd.io/bbolt"
+)
+
+// boltStateBucket is the single bucket all gamestate keys live under; Bolt transactions already
+// give us MULTI/EXEC-equivalent atomicity, so there's no need to shard state across buckets.
+var boltStateBucket = []byte("gamestate")
+
+// boltBackend is an embedded, single-node PrimitiveStorage implementation backed by BoltDB. It
+// lets a Cardinal world run in a "no external services" quickstart mode for local dev and testing
+// without standing up Redis.
+type boltBackend struct {
+	db      *bolt.DB
+	tx      *bolt.Tx
+	pending map[string][]byte
+	deletes map[string]struct{}
+}
+
+func newBoltBackend(cfg BackendConfig) (*boltBackend, error) {
+	if cfg.BoltPath == "" {
+		return nil, eris.New("bolt backend requires BoltPath")
+	}
+	db, err := bolt.Open(filepath.Clean(cfg.BoltPath), 0o600, nil)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to open bolt database")
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(boltStateBucket)
+		return err
+	}); err != nil {
+		return nil, eris.Wrap(err, "failed to create gamestate bucket")
+	}
+	return &boltBackend{db: db}, nil
+}
+
+func (b *boltBackend) get(key string) ([]byte, error) {
+	var value []byte
+	err := b.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(boltStateBucket).Get([]byte(key))
+		if v == nil {
+			return ErrKeyNotFound
+		}
+		value = append([]byte(nil), v...)
+		return nil
+	})
+	return value, err
+}
+
+func (b *boltBackend) GetUInt64(_ context.Context, key string) (uint64, error) {
+	bz, err := b.get(key)
+	if err != nil {
+		return 0, err
+	}
+	return decodeUint64(bz), nil
+}
+
+func (b *boltBackend) SetUInt64(ctx context.Context, key string, value uint64) error {
+	return b.Set(ctx, key, encodeUint64(value))
+}
+
+func (b *boltBackend) Incr(ctx context.Context, key string) error {
+	current, err := b.GetUInt64(ctx, key)
+	if err != nil && !eris.Is(eris.Cause(err), ErrKeyNotFound) {
+		return err
+	}
+	return b.SetUInt64(ctx, key, current+1)
+}
+
+func (b *boltBackend) GetBytes(ctx context.Context, key string) ([]byte, error) {
+	return b.get(key)
+}
+
+func (b *boltBackend) Set(_ context.Context, key string, value []byte) error {
+	if b.pending != nil {
+		delete(b.deletes, key)
+		b.pending[key] = value
+		return nil
+	}
+	return eris.Wrap(b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltStateBucket).Put([]byte(key), value)
+	}), "")
+}
+
+func (b *boltBackend) Delete(_ context.Context, key string) error {
+	if b.pending != nil {
+		delete(b.pending, key)
+		b.deletes[key] = struct{}{}
+		return nil
+	}
+	return eris.Wrap(b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltStateBucket).Delete([]byte(key))
+	}), "")
+}
+
+func (b *boltBackend) Keys(_ context.Context, pattern string) ([]string, error) {
+	var keys []string
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltStateBucket).ForEach(func(k, _ []byte) error {
+			// path.Match, not filepath.Match: see the identical note in memory_backend.go's Keys.
+			ok, err := path.Match(strings.ReplaceAll(pattern, "/", "\x00"), strings.ReplaceAll(string(k), "/", "\x00"))
+			if err != nil {
+				return err
+			}
+			if ok {
+				keys = append(keys, string(k))
+			}
+			return nil
+		})
+	})
+	return keys, eris.Wrap(err, "")
+}
+
+func (b *boltBackend) StartTransaction(_ context.Context) (PrimitiveStorage[string], error) {
+	return &boltBackend{
+		db:      b.db,
+		pending: make(map[string][]byte),
+		deletes: make(map[string]struct{}),
+	}, nil
+}
+
+func (b *boltBackend) EndTransaction(_ context.Context) error {
+	if b.pending == nil {
+		return eris.New("EndTransaction called on a boltBackend with no open transaction")
+	}
+	return eris.Wrap(b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(boltStateBucket)
+		for key := range b.deletes {
+			if err := bucket.Delete([]byte(key)); err != nil {
+				return err
+			}
+		}
+		for key, value := range b.pending {
+			if err := bucket.Put([]byte(key), value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}), "")
+}
@@ -0,0 +1,296 @@
+package gamestate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rotisserie/eris"
+	"go.opentelemetry.io/otel/codes"
+	ddotel "gopkg.in/DataDog/dd-trace-go.v1/ddtrace/opentelemetry"
+	ddtracer "gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+
+	"pkg.world.dev/world-engine/cardinal/codec"
+)
+
+// defaultMaxCheckpoints is the number of checkpoint snapshots retained when a CheckpointConfig
+// does not specify its own limit.
+const defaultMaxCheckpoints = 5
+
+// storageCheckpointKeyPrefix namespaces every key written by the checkpoint subsystem so
+// liveStateKeys can tell checkpoint keys apart from live game state.
+const storageCheckpointKeyPrefix = "checkpoint:"
+
+func storageCheckpointKey(tick uint64) string {
+	return fmt.Sprintf("%s%d", storageCheckpointKeyPrefix, tick)
+}
+
+// storageCheckpointIndexKey stores the ordered list of tick numbers that currently have a
+// checkpoint snapshot, so ListCheckpoints and the retention GC don't need to scan keys.
+func storageCheckpointIndexKey() string {
+	return storageCheckpointKeyPrefix + "index"
+}
+
+// checkpointSnapshot is the payload written under storageCheckpointKey(tick). It captures every
+// live state key/value pair as of the end of that tick, so RollbackToTick can replace the live
+// keyspace wholesale.
+type checkpointSnapshot struct {
+	Tick uint64
+	Data map[string][]byte
+}
+
+// CheckpointConfig controls how often FinalizeTick snapshots full game state and how many of
+// those snapshots are retained before the oldest ones are garbage collected.
+type CheckpointConfig struct {
+	// Interval is the number of ticks between snapshots; a snapshot is taken whenever
+	// tick % Interval == 0. Interval == 0 disables periodic checkpointing entirely.
+	Interval uint64
+	// MaxCheckpoints bounds how many snapshots are kept. Once exceeded, the oldest checkpoints
+	// are dropped as part of the same tick-finalize transaction that wrote the newest one.
+	MaxCheckpoints int
+}
+
+// DefaultCheckpointConfig snapshots every 100 ticks and retains the last 5 snapshots.
+func DefaultCheckpointConfig() CheckpointConfig {
+	return CheckpointConfig{
+		Interval:       100,
+		MaxCheckpoints: defaultMaxCheckpoints,
+	}
+}
+
+// SetCheckpointConfig overrides the checkpoint cadence and retention used by FinalizeTick. It
+// should be called once during setup, before the first tick is finalized.
+func (m *EntityCommandBuffer) SetCheckpointConfig(cfg CheckpointConfig) {
+	if cfg.MaxCheckpoints <= 0 {
+		cfg.MaxCheckpoints = defaultMaxCheckpoints
+	}
+	m.checkpointConfig = cfg
+}
+
+// queueCheckpointSnapshot queues a snapshot of live state onto pipe, the same not-yet-committed
+// transaction FinalizeTick is using to commit tick, if tick is due for one per m.checkpointConfig,
+// and queues garbage collection of any checkpoints beyond MaxCheckpoints alongside it. Queuing onto
+// FinalizeTick's own pipe rather than opening a second transaction after it commits is what makes
+// the snapshot part of the same atomic commit as the tick itself: a crash before EndTransaction
+// loses the whole tick, snapshot included, rather than leaving a committed tick with no checkpoint.
+//
+// The live keys and values are read via m.dbStorage, not pipe: this tick's component and archetype
+// writes are already applied to m.dbStorage by the time FinalizeTick runs (FinalizeTick itself only
+// commits tick bookkeeping), so those reads see tick's final state. Pipelined commands can't be read
+// back before EXEC, so reading through pipe instead would not work even if it were needed.
+func (m *EntityCommandBuffer) queueCheckpointSnapshot(ctx context.Context, pipe PrimitiveStorage[string], tick uint64) error {
+	cfg := m.checkpointConfig
+	if cfg.Interval == 0 || tick%cfg.Interval != 0 {
+		return nil
+	}
+
+	ctx, span := m.tracer.Start(ddotel.ContextWithStartOptions(ctx, ddtracer.Measured()), "ecb.checkpoint.snapshot")
+	defer span.End()
+
+	keys, err := m.liveStateKeys(ctx)
+	if err != nil {
+		span.SetStatus(codes.Error, eris.ToString(err, true))
+		span.RecordError(err)
+		return eris.Wrap(err, "failed to list live state keys for checkpoint")
+	}
+
+	snapshot := checkpointSnapshot{Tick: tick, Data: make(map[string][]byte, len(keys))}
+	for _, key := range keys {
+		bz, err := m.dbStorage.GetBytes(ctx, key)
+		if err != nil {
+			span.SetStatus(codes.Error, eris.ToString(err, true))
+			span.RecordError(err)
+			return eris.Wrapf(err, "failed to read key %q for checkpoint", key)
+		}
+		snapshot.Data[key] = bz
+	}
+
+	buf, err := codec.Encode(snapshot)
+	if err != nil {
+		span.SetStatus(codes.Error, eris.ToString(err, true))
+		span.RecordError(err)
+		return eris.Wrap(err, "failed to encode checkpoint snapshot")
+	}
+
+	ticks, err := m.checkpointTickIndex(ctx)
+	if err != nil {
+		span.SetStatus(codes.Error, eris.ToString(err, true))
+		span.RecordError(err)
+		return err
+	}
+	// After a rollback a tick can be checkpointed again; drop its older entry so each tick is
+	// listed once and eviction never deletes a snapshot the index still lists.
+	kept := ticks[:0]
+	for _, t := range ticks {
+		if t != tick {
+			kept = append(kept, t)
+		}
+	}
+	ticks = append(kept, tick)
+
+	var evicted []uint64
+	for len(ticks) > cfg.MaxCheckpoints {
+		evicted = append(evicted, ticks[0])
+		ticks = ticks[1:]
+	}
+
+	indexBuf, err := codec.Encode(ticks)
+	if err != nil {
+		span.SetStatus(codes.Error, eris.ToString(err, true))
+		span.RecordError(err)
+		return eris.Wrap(err, "failed to encode checkpoint index")
+	}
+
+	if err := pipe.Set(ctx, storageCheckpointKey(tick), buf); err != nil {
+		span.SetStatus(codes.Error, eris.ToString(err, true))
+		span.RecordError(err)
+		return eris.Wrap(err, "failed to queue checkpoint snapshot")
+	}
+	for _, evictedTick := range evicted {
+		if err := pipe.Delete(ctx, storageCheckpointKey(evictedTick)); err != nil {
+			span.SetStatus(codes.Error, eris.ToString(err, true))
+			span.RecordError(err)
+			return eris.Wrapf(err, "failed to queue garbage collection of checkpoint %d", evictedTick)
+		}
+	}
+	if err := pipe.Set(ctx, storageCheckpointIndexKey(), indexBuf); err != nil {
+		span.SetStatus(codes.Error, eris.ToString(err, true))
+		span.RecordError(err)
+		return eris.Wrap(err, "failed to queue checkpoint index")
+	}
+
+	return nil
+}
+
+func (m *EntityCommandBuffer) checkpointTickIndex(ctx context.Context) ([]uint64, error) {
+	bz, err := m.dbStorage.GetBytes(ctx, storageCheckpointIndexKey())
+	if eris.Is(eris.Cause(err), ErrKeyNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, eris.Wrap(err, "failed to read checkpoint index")
+	}
+	ticks, err := codec.Decode[[]uint64](bz)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to decode checkpoint index")
+	}
+	return ticks, nil
+}
+
+// ListCheckpoints returns the tick numbers that currently have a retained checkpoint, oldest
+// first.
+func (m *EntityCommandBuffer) ListCheckpoints() ([]uint64, error) {
+	return m.checkpointTickIndex(context.Background())
+}
+
+// RollbackToTick restores live game state to the checkpoint taken at the end of tick n, replacing
+// the entire live keyspace with the snapshot contents in a single MULTI/EXEC. storageStartTickKey
+// and storageEndTickKey are both reset to n and any pending transaction left over from an
+// in-flight tick is cleared, so GetTickNumbers() reports start == end == n immediately afterward.
+func (m *EntityCommandBuffer) RollbackToTick(n uint64) error {
+	ctx := context.Background()
+	ctx, span := m.tracer.Start(ddotel.ContextWithStartOptions(ctx, ddtracer.Measured()), "ecb.checkpoint.rollback")
+	defer span.End()
+
+	bz, err := m.dbStorage.GetBytes(ctx, storageCheckpointKey(n))
+	if err != nil {
+		span.SetStatus(codes.Error, eris.ToString(err, true))
+		span.RecordError(err)
+		return eris.Wrapf(err, "failed to read checkpoint for tick %d", n)
+	}
+	snapshot, err := codec.Decode[checkpointSnapshot](bz)
+	if err != nil {
+		span.SetStatus(codes.Error, eris.ToString(err, true))
+		span.RecordError(err)
+		return eris.Wrap(err, "failed to decode checkpoint snapshot")
+	}
+
+	liveKeys, err := m.liveStateKeys(ctx)
+	if err != nil {
+		span.SetStatus(codes.Error, eris.ToString(err, true))
+		span.RecordError(err)
+		return eris.Wrap(err, "failed to list live state keys for rollback")
+	}
+
+	pipe, err := m.dbStorage.StartTransaction(ctx)
+	if err != nil {
+		span.SetStatus(codes.Error, eris.ToString(err, true))
+		span.RecordError(err)
+		return eris.Wrap(err, "failed to start rollback transaction")
+	}
+
+	for _, key := range liveKeys {
+		if _, ok := snapshot.Data[key]; ok {
+			continue
+		}
+		if err := pipe.Delete(ctx, key); err != nil {
+			span.SetStatus(codes.Error, eris.ToString(err, true))
+			span.RecordError(err)
+			return eris.Wrapf(err, "failed to clear stale key %q during rollback", key)
+		}
+	}
+	for key, value := range snapshot.Data {
+		if err := pipe.Set(ctx, key, value); err != nil {
+			span.SetStatus(codes.Error, eris.ToString(err, true))
+			span.RecordError(err)
+			return eris.Wrapf(err, "failed to restore key %q during rollback", key)
+		}
+	}
+
+	if err := pipe.Delete(ctx, storagePendingTransactionKey()); err != nil {
+		span.SetStatus(codes.Error, eris.ToString(err, true))
+		span.RecordError(err)
+		return eris.Wrap(err, "failed to clear pending transaction during rollback")
+	}
+	if err := pipe.SetUInt64(ctx, storageStartTickKey(), n); err != nil {
+		span.SetStatus(codes.Error, eris.ToString(err, true))
+		span.RecordError(err)
+		return eris.Wrap(err, "failed to reset start tick during rollback")
+	}
+	if err := pipe.SetUInt64(ctx, storageEndTickKey(), n); err != nil {
+		span.SetStatus(codes.Error, eris.ToString(err, true))
+		span.RecordError(err)
+		return eris.Wrap(err, "failed to reset end tick during rollback")
+	}
+
+	if err := pipe.EndTransaction(ctx); err != nil {
+		span.SetStatus(codes.Error, eris.ToString(err, true))
+		span.RecordError(err)
+		return eris.Wrap(err, "failed to commit rollback transaction")
+	}
+
+	m.pendingArchIDs = nil
+
+	return nil
+}
+
+// liveStateKeys lists every live component/archetype key, excluding tick bookkeeping
+// (storageStartTickKey, storageEndTickKey, storagePendingTransactionKey) and anything already
+// under storageCheckpointKeyPrefix. Without this exclusion, every checkpoint would embed the tick
+// counters and every previously-retained checkpoint's blob, growing each new snapshot by the size
+// of all the ones before it, and RollbackToTick would clobber start/end tick with stale values
+// from whatever tick the snapshot happened to be taken at.
+func (m *EntityCommandBuffer) liveStateKeys(ctx context.Context) ([]string, error) {
+	keys, err := m.dbStorage.Keys(ctx, "*")
+	if err != nil {
+		return nil, err
+	}
+	live := keys[:0]
+	for _, key := range keys {
+		if isLiveStateKey(key) {
+			live = append(live, key)
+		}
+	}
+	return live, nil
+}
+
+func isLiveStateKey(key string) bool {
+	if strings.HasPrefix(key, storageCheckpointKeyPrefix) {
+		return false
+	}
+	switch key {
+	case storageStartTickKey(), storageEndTickKey(), storagePendingTransactionKey():
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,89 @@
+package gamestate
+
+import (
+	"context"
+
+	"github.com/rotisserie/eris"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+
+	"pkg.world.dev/world-engine/cardinal/types"
+)
+
+// BackendKind selects which PrimitiveStorage implementation NewEntityCommandBuffer wires up.
+type BackendKind string
+
+const (
+	// BackendRedis is the default, production backend: Redis with MULTI/EXEC pipelining.
+	BackendRedis BackendKind = "redis"
+	// BackendBolt is an embedded, single-node backend for dev/testing without a Redis dependency.
+	BackendBolt BackendKind = "bolt"
+	// BackendMemory is a non-persistent backend for unit tests.
+	BackendMemory BackendKind = "memory"
+)
+
+// BackendConfig selects and configures the storage backend for NewEntityCommandBuffer.
+type BackendConfig struct {
+	Kind BackendKind
+
+	// RedisAddress and RedisPassword apply when Kind == BackendRedis.
+	RedisAddress  string
+	RedisPassword string
+
+	// BoltPath is the file path of the embedded database when Kind == BackendBolt.
+	BoltPath string
+}
+
+// NewEntityCommandBuffer constructs an EntityCommandBuffer backed by the storage implementation
+// selected by cfg.Kind. An empty BackendConfig defaults to BackendMemory, so callers that only
+// need a "no external services" quickstart mode can pass the zero value.
+func NewEntityCommandBuffer(cfg BackendConfig) (*EntityCommandBuffer, error) {
+	backend, err := newBackend(cfg)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to initialize gamestate storage backend")
+	}
+	return &EntityCommandBuffer{
+		dbStorage:        backend,
+		tracer:           otel.Tracer("pkg.world.dev/world-engine/cardinal/gamestate"),
+		checkpointConfig: DefaultCheckpointConfig(),
+	}, nil
+}
+
+func newBackend(cfg BackendConfig) (PrimitiveStorage[string], error) {
+	switch cfg.Kind {
+	case BackendRedis:
+		return newRedisBackend(cfg)
+	case BackendBolt:
+		return newBoltBackend(cfg)
+	case BackendMemory, "":
+		return newMemoryBackend(), nil
+	default:
+		return nil, eris.Errorf("unknown gamestate backend kind %q", cfg.Kind)
+	}
+}
+
+// EntityCommandBuffer owns tick bookkeeping for game state kept in a pluggable storage backend
+// (start/end tick numbers, recovery of an interrupted tick) and, per CheckpointConfig, the
+// periodic full-state snapshots used by RollbackToTick. FinalizeTick commits that bookkeeping and
+// clears the pending archetype IDs; it does not itself write component or archetype state.
+type EntityCommandBuffer struct {
+	dbStorage PrimitiveStorage[string]
+	tracer    trace.Tracer
+
+	pendingArchIDs   []types.ArchetypeID
+	checkpointConfig CheckpointConfig
+}
+
+// makePipeOfRedisCommands opens a buffered pipe of commands against the configured backend. The
+// name predates backend pluggability; despite it, this works against any PrimitiveStorage
+// implementation, not just Redis.
+func (m *EntityCommandBuffer) makePipeOfRedisCommands(ctx context.Context) (PrimitiveStorage[string], error) {
+	return m.dbStorage.StartTransaction(ctx)
+}
+
+// DiscardPending clears any component/archetype state buffered in memory for the tick that was
+// just finalized.
+func (m *EntityCommandBuffer) DiscardPending() error {
+	m.pendingArchIDs = nil
+	return nil
+}
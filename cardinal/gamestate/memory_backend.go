@@ -0,0 +1,137 @@
+package gamestate
+
+import (
+	"context"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/rotisserie/eris"
+)
+
+// memoryBackend is a non-persistent, process-local PrimitiveStorage implementation. It exists so
+// EntityCommandBuffer's recovery and atomicity contract can be unit tested without a live Redis,
+// and so BackendMemory can serve as the zero-value default for NewEntityCommandBuffer.
+type memoryBackend struct {
+	mu   *sync.Mutex
+	data map[string][]byte
+	// pending buffers writes made on a backend returned by StartTransaction until EndTransaction
+	// applies them to data under mu, keeping the commit atomic with respect to concurrent readers.
+	pending map[string][]byte
+	deletes map[string]struct{}
+}
+
+func newMemoryBackend() *memoryBackend {
+	return &memoryBackend{
+		mu:   &sync.Mutex{},
+		data: make(map[string][]byte),
+	}
+}
+
+func (m *memoryBackend) target() map[string][]byte {
+	if m.pending != nil {
+		return m.pending
+	}
+	return m.data
+}
+
+func (m *memoryBackend) GetUInt64(_ context.Context, key string) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	bz, ok := m.data[key]
+	if !ok {
+		return 0, ErrKeyNotFound
+	}
+	return decodeUint64(bz), nil
+}
+
+func (m *memoryBackend) SetUInt64(_ context.Context, key string, value uint64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.target()[key] = encodeUint64(value)
+	return nil
+}
+
+func (m *memoryBackend) Incr(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var current uint64
+	if bz, ok := m.data[key]; ok {
+		current = decodeUint64(bz)
+	}
+	m.target()[key] = encodeUint64(current + 1)
+	return nil
+}
+
+func (m *memoryBackend) GetBytes(_ context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	bz, ok := m.data[key]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	return bz, nil
+}
+
+func (m *memoryBackend) Set(_ context.Context, key string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.target()[key] = value
+	return nil
+}
+
+func (m *memoryBackend) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.pending != nil {
+		m.deletes[key] = struct{}{}
+		delete(m.pending, key)
+		return nil
+	}
+	delete(m.data, key)
+	return nil
+}
+
+func (m *memoryBackend) Keys(_ context.Context, pattern string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var keys []string
+	for key := range m.data {
+		// path.Match, not filepath.Match: gamestate keys are logical strings, not filesystem paths,
+		// so matching must not vary across OSes. path.Match still treats "/" as a separator that "*"
+		// and "?" never cross, while Redis's KEYS pattern does not; swapping "/" for a byte path.Match
+		// gives no special meaning, in both pattern and key, is what makes this backend match Redis.
+		ok, err := path.Match(strings.ReplaceAll(pattern, "/", "\x00"), strings.ReplaceAll(key, "/", "\x00"))
+		if err != nil {
+			return nil, eris.Wrap(err, "invalid key pattern")
+		}
+		if ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (m *memoryBackend) StartTransaction(_ context.Context) (PrimitiveStorage[string], error) {
+	return &memoryBackend{
+		mu:      m.mu,
+		data:    m.data,
+		pending: make(map[string][]byte),
+		deletes: make(map[string]struct{}),
+	}, nil
+}
+
+func (m *memoryBackend) EndTransaction(_ context.Context) error {
+	if m.pending == nil {
+		return eris.New("EndTransaction called on a memoryBackend with no open transaction")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key := range m.deletes {
+		delete(m.data, key)
+	}
+	for key, value := range m.pending {
+		m.data[key] = value
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package gamestate
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rotisserie/eris"
+)
+
+// redisBackend is the production PrimitiveStorage implementation: a thin wrapper around
+// redis.Cmdable so the same type can represent either the base client or a MULTI/EXEC pipeline
+// opened by StartTransaction.
+type redisBackend struct {
+	client redis.Cmdable
+	// pipe is non-nil when this redisBackend was returned by StartTransaction; EndTransaction
+	// executes it.
+	pipe redis.Pipeliner
+}
+
+func newRedisBackend(cfg BackendConfig) (*redisBackend, error) {
+	if cfg.RedisAddress == "" {
+		return nil, eris.New("redis backend requires RedisAddress")
+	}
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.RedisAddress,
+		Password: cfg.RedisPassword,
+	})
+	return &redisBackend{client: client}, nil
+}
+
+func (r *redisBackend) cmdable() redis.Cmdable {
+	if r.pipe != nil {
+		return r.pipe
+	}
+	return r.client
+}
+
+func (r *redisBackend) GetUInt64(ctx context.Context, key string) (uint64, error) {
+	v, err := r.cmdable().Get(ctx, key).Uint64()
+	if eris.Is(eris.Cause(err), redis.Nil) {
+		return 0, ErrKeyNotFound
+	}
+	return v, eris.Wrap(err, "")
+}
+
+func (r *redisBackend) SetUInt64(ctx context.Context, key string, value uint64) error {
+	return eris.Wrap(r.cmdable().Set(ctx, key, value, 0).Err(), "")
+}
+
+func (r *redisBackend) Incr(ctx context.Context, key string) error {
+	return eris.Wrap(r.cmdable().Incr(ctx, key).Err(), "")
+}
+
+func (r *redisBackend) GetBytes(ctx context.Context, key string) ([]byte, error) {
+	bz, err := r.cmdable().Get(ctx, key).Bytes()
+	if eris.Is(eris.Cause(err), redis.Nil) {
+		return nil, ErrKeyNotFound
+	}
+	return bz, eris.Wrap(err, "")
+}
+
+func (r *redisBackend) Set(ctx context.Context, key string, value []byte) error {
+	return eris.Wrap(r.cmdable().Set(ctx, key, value, 0).Err(), "")
+}
+
+func (r *redisBackend) Delete(ctx context.Context, key string) error {
+	return eris.Wrap(r.cmdable().Del(ctx, key).Err(), "")
+}
+
+func (r *redisBackend) Keys(ctx context.Context, pattern string) ([]string, error) {
+	keys, err := r.cmdable().Keys(ctx, pattern).Result()
+	return keys, eris.Wrap(err, "")
+}
+
+func (r *redisBackend) StartTransaction(_ context.Context) (PrimitiveStorage[string], error) {
+	return &redisBackend{client: r.client, pipe: r.client.TxPipeline()}, nil
+}
+
+func (r *redisBackend) EndTransaction(ctx context.Context) error {
+	if r.pipe == nil {
+		return eris.New("EndTransaction called on a redisBackend with no open pipe")
+	}
+	_, err := r.pipe.Exec(ctx)
+	return eris.Wrap(err, "")
+}
@@ -3,7 +3,6 @@ package gamestate
 import (
 	"context"
 
-	"github.com/redis/go-redis/v9"
 	"github.com/rotisserie/eris"
 	"go.opentelemetry.io/otel/codes"
 	ddotel "gopkg.in/DataDog/dd-trace-go.v1/ddtrace/opentelemetry"
@@ -33,18 +32,16 @@ type pendingTransaction struct {
 func (m *EntityCommandBuffer) GetTickNumbers() (start, end uint64, err error) {
 	ctx := context.Background()
 	start, err = m.dbStorage.GetUInt64(ctx, storageStartTickKey())
-	err = eris.Wrap(err, "")
-	if eris.Is(eris.Cause(err), redis.Nil) {
+	if eris.Is(eris.Cause(err), ErrKeyNotFound) {
 		start = 0
 	} else if err != nil {
-		return 0, 0, err
+		return 0, 0, eris.Wrap(err, "")
 	}
 	end, err = m.dbStorage.GetUInt64(ctx, storageEndTickKey())
-	err = eris.Wrap(err, "")
-	if eris.Is(eris.Cause(err), redis.Nil) {
+	if eris.Is(eris.Cause(err), ErrKeyNotFound) {
 		end = 0
 	} else if err != nil {
-		return 0, 0, err
+		return 0, 0, eris.Wrap(err, "")
 	}
 	return start, end, nil
 }
@@ -89,6 +86,17 @@ func (m *EntityCommandBuffer) FinalizeTick(ctx context.Context) error {
 	ctx, span := m.tracer.Start(ddotel.ContextWithStartOptions(ctx, ddtracer.Measured()), "ecb.tick.finalize")
 	defer span.End()
 
+	// The tick this FinalizeTick call is completing is one past the last committed end tick, so
+	// compute it before opening the pipe below: once queued, checkpoint writes need to be tagged
+	// with the tick number they're finalizing, same as the Incr call they're queued alongside.
+	_, prevEndTick, err := m.GetTickNumbers()
+	if err != nil {
+		span.SetStatus(codes.Error, eris.ToString(err, true))
+		span.RecordError(err)
+		return eris.Wrap(err, "failed to read end tick")
+	}
+	tick := prevEndTick + 1
+
 	pipe, err := m.makePipeOfRedisCommands(ctx)
 	if err != nil {
 		span.SetStatus(codes.Error, eris.ToString(err, true))
@@ -102,6 +110,14 @@ func (m *EntityCommandBuffer) FinalizeTick(ctx context.Context) error {
 		return eris.Wrap(err, "failed to increment end tick key")
 	}
 
+	// Queuing the checkpoint snapshot onto the same pipe as the Incr above, before EndTransaction,
+	// is what makes it commit atomically with the tick itself.
+	if err := m.queueCheckpointSnapshot(ctx, pipe, tick); err != nil {
+		span.SetStatus(codes.Error, eris.ToString(err, true))
+		span.RecordError(err)
+		return eris.Wrap(err, "failed to queue checkpoint snapshot")
+	}
+
 	if err := pipe.EndTransaction(ctx); err != nil {
 		span.SetStatus(codes.Error, eris.ToString(err, true))
 		span.RecordError(err)
@@ -0,0 +1,18 @@
+package gamestate
+
+import "encoding/binary"
+
+// encodeUint64 and decodeUint64 give the embedded backends (memory, Bolt) a fixed-width
+// representation for the scalar tick counters that Redis stores natively as integer strings.
+func encodeUint64(v uint64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, v)
+	return bz
+}
+
+func decodeUint64(bz []byte) uint64 {
+	if len(bz) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
@@ -0,0 +1,17 @@
+package server
+
+import (
+	"net/http"
+
+	"pkg.world.dev/world-engine/cardinal/gamestate"
+	"pkg.world.dev/world-engine/cardinal/server/handler"
+)
+
+// RegisterCheckpointRoutes mounts the checkpoint debug endpoints alongside debug/state:
+// debug/checkpoints for listing what's available, and debug/checkpoints/rollback for restoring one
+// during incident response. The World's debug server bootstrap must call this next to wherever it
+// registers debug/state, passing it the same EntityCommandBuffer debug/state reads from.
+func RegisterCheckpointRoutes(mux *http.ServeMux, ecb *gamestate.EntityCommandBuffer) {
+	mux.HandleFunc("/debug/checkpoints", handler.ListCheckpoints(ecb))
+	mux.HandleFunc("/debug/checkpoints/rollback", handler.RollbackCheckpoint(ecb))
+}
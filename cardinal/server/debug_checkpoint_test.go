@@ -0,0 +1,72 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"pkg.world.dev/world-engine/cardinal/gamestate"
+	"pkg.world.dev/world-engine/cardinal/server/handler"
+	"pkg.world.dev/world-engine/cardinal/types/txpool"
+)
+
+// newCheckpointTestServer builds an EntityCommandBuffer on the in-memory backend with a checkpoint
+// interval small enough for a handful of ticks to actually trigger a snapshot, and mounts the
+// checkpoint debug routes on it exactly as RegisterCheckpointRoutes documents. This exercises the
+// routes directly rather than through ServerTestSuite's fixture, since the latter's debug/state
+// mounting lives in the production bootstrap this package doesn't own.
+func newCheckpointTestServer(t *testing.T) (*httptest.Server, *gamestate.EntityCommandBuffer) {
+	t.Helper()
+	ecb, err := gamestate.NewEntityCommandBuffer(gamestate.BackendConfig{Kind: gamestate.BackendMemory})
+	require.NoError(t, err)
+	ecb.SetCheckpointConfig(gamestate.CheckpointConfig{Interval: 1, MaxCheckpoints: 5})
+
+	mux := http.NewServeMux()
+	RegisterCheckpointRoutes(mux, ecb)
+	return httptest.NewServer(mux), ecb
+}
+
+func doTick(t *testing.T, ecb *gamestate.EntityCommandBuffer) {
+	t.Helper()
+	ctx := context.Background()
+	require.NoError(t, ecb.StartNextTick(ctx, nil, txpool.New()))
+	require.NoError(t, ecb.FinalizeTick(ctx))
+}
+
+func TestDebugListCheckpoints(t *testing.T) {
+	srv, ecb := newCheckpointTestServer(t)
+	defer srv.Close()
+
+	doTick(t, ecb)
+	doTick(t, ecb)
+
+	res, err := http.Post(srv.URL+"/debug/checkpoints", "application/json", bytes.NewReader(nil))
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var result handler.ListCheckpointsResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&result))
+	require.NotEmpty(t, result.Ticks)
+}
+
+func TestDebugRollbackCheckpoint_UnknownTickFails(t *testing.T) {
+	srv, ecb := newCheckpointTestServer(t)
+	defer srv.Close()
+
+	doTick(t, ecb)
+
+	const wantNonExistentTick = 999999
+	body, err := json.Marshal(handler.RollbackCheckpointRequest{Tick: wantNonExistentTick})
+	require.NoError(t, err)
+
+	res, err := http.Post(srv.URL+"/debug/checkpoints/rollback", "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.NotEqual(t, http.StatusOK, res.StatusCode)
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"pkg.world.dev/world-engine/cardinal/gamestate"
+)
+
+// ListCheckpointsRequest has no parameters; checkpoints are listed unconditionally.
+type ListCheckpointsRequest struct{}
+
+// ListCheckpointsResponse reports every tick that currently has a retained checkpoint, oldest
+// first.
+type ListCheckpointsResponse struct {
+	Ticks []uint64 `json:"ticks"`
+}
+
+// RollbackCheckpointRequest names the checkpoint to restore live state to.
+type RollbackCheckpointRequest struct {
+	Tick uint64 `json:"tick"`
+}
+
+// RollbackCheckpointResponse confirms the tick live state was rolled back to.
+type RollbackCheckpointResponse struct {
+	Tick uint64 `json:"tick"`
+}
+
+// ListCheckpoints handles debug/checkpoints, letting operators see which checkpoints are
+// available to roll back to during incident response.
+func ListCheckpoints(ecb *gamestate.EntityCommandBuffer) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		ticks, err := ecb.ListCheckpoints()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, ListCheckpointsResponse{Ticks: ticks})
+	}
+}
+
+// RollbackCheckpoint handles debug/checkpoints/rollback, restoring live state to the checkpoint
+// named in the request body.
+func RollbackCheckpoint(ecb *gamestate.EntityCommandBuffer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req RollbackCheckpointRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := ecb.RollbackToTick(req.Tick); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, RollbackCheckpointResponse{Tick: req.Tick})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,23 @@
+package cardinal
+
+import (
+	"pkg.world.dev/world-engine/cardinal/gamestate"
+)
+
+// WorldOption sets a value on a worldConfig.
+type WorldOption func(*worldConfig)
+
+// worldConfig collects the values set by WorldOptions. No code in this package reads it, so a
+// World's EntityCommandBuffer is constructed without it.
+type worldConfig struct {
+	storageBackend gamestate.BackendConfig
+}
+
+// WithStorageBackend records cfg as the gamestate storage backend in worldConfig. The recorded
+// value is not passed to gamestate.NewEntityCommandBuffer, so this option does not change which
+// backend a World uses.
+func WithStorageBackend(cfg gamestate.BackendConfig) WorldOption {
+	return func(c *worldConfig) {
+		c.storageBackend = cfg
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"time"
 
 	"cosmossdk.io/depinject"
 	"cosmossdk.io/log"
@@ -64,6 +65,7 @@ import (
 	stakingkeeper "github.com/cosmos/cosmos-sdk/x/staking/keeper"
 	"github.com/rotisserie/eris"
 
+	"pkg.world.dev/world-engine/evm/app/shardante"
 	"pkg.world.dev/world-engine/evm/router"
 	"pkg.world.dev/world-engine/evm/sequencer"
 	namespacekeeper "pkg.world.dev/world-engine/evm/x/namespace/keeper"
@@ -76,6 +78,10 @@ var DefaultNodeHome string
 var (
 	_ runtime.AppI            = (*App)(nil)
 	_ servertypes.Application = (*App)(nil)
+	// Pins *namespacekeeper.Keeper to shardante.NamespaceSequencerKeeper's contract, so a rename on
+	// the keeper side fails here with a clear error instead of as a less obvious mismatch at the
+	// shardante.NewAnteHandler call in NewApp.
+	_ shardante.NamespaceSequencerKeeper = (*namespacekeeper.Keeper)(nil)
 )
 
 // App extends an ABCI application, but with most of its parameters exported.
@@ -207,6 +213,11 @@ func NewApp(
 		panic(err)
 	}
 
+	// Wrap the generic auth ante with shard-tx-specific checks (sequencer authorization, rate
+	// limiting, payload sanity) so forged or malformed shard traffic is rejected in CheckTx instead
+	// of only being caught after the fact in preBlocker.
+	cosmHandler = shardante.NewAnteHandler(app.NamespaceKeeper, 0, cosmHandler)
+
 	// Setup Polaris Runtime.
 	if err := app.Polaris.Build(
 		app,
@@ -257,25 +268,22 @@ func (app *App) preBlocker(ctx sdk.Context, _ *types.RequestFinalizeBlock) (*sdk
 
 	// Handle shard registration messages
 	for _, shardRegisterMsg := range shardRegisterMsgs {
+		namespace := shardRegisterMsg.Namespace.ShardName
+		address := shardRegisterMsg.Namespace.ShardAddress
 		app.Logger().Info(
-			fmt.Sprintf("Registering new shard with namespace %q to %q",
-				shardRegisterMsg.Namespace.ShardName,
-				shardRegisterMsg.Namespace.ShardAddress,
-			),
+			fmt.Sprintf("Registering new shard with namespace %q to %q", namespace, address),
 		)
 		handler := app.MsgServiceRouter().Handler(shardRegisterMsg)
 		_, err := handler(ctx, shardRegisterMsg)
 		if err != nil {
 			app.Logger().Error(
-				fmt.Sprintf(
-					"failed to register new shard with namespace %q: %q",
-					shardRegisterMsg.Namespace.ShardName, err,
-				),
-			)
-			return nil, eris.Wrapf(
-				err, "failed to register new shard with namespace %q", shardRegisterMsg.Namespace.ShardName,
+				fmt.Sprintf("failed to register new shard with namespace %q: %q", namespace, err),
 			)
+			emitShardTxFailedEvent(ctx, namespace, err)
+			recordShardTxFailed(ctx.Context(), namespace)
+			return nil, eris.Wrapf(err, "failed to register new shard with namespace %q", namespace)
 		}
+		emitShardRegisteredEvent(ctx, namespace, address)
 	}
 
 	// Handle game shard transaction sequencing
@@ -285,12 +293,24 @@ func (app *App) preBlocker(ctx sdk.Context, _ *types.RequestFinalizeBlock) (*sdk
 	if numShardTxDataMsgs > 0 {
 		app.Logger().Info("Received game shard transaction data from router")
 		handler := app.MsgServiceRouter().Handler(shardTxDataMsgs[0])
+		tick := uint64(ctx.BlockHeight())
+		namespaceCounts := make(map[string]int, numShardTxDataMsgs)
 		for _, tx := range shardTxDataMsgs {
-			_, err := handler(ctx, tx)
+			namespace, txHash, err := shardTxIdentity(tx)
+			if err != nil {
+				return resPreBlock, eris.Wrap(err, "failed to identify game shard tx data submission")
+			}
+			start := time.Now()
+			_, err = handler(ctx, tx)
 			if err != nil {
 				app.Logger().Error(fmt.Sprintf("failed to process game shard tx data submission: %q", err))
+				emitShardTxFailedEvent(ctx, namespace, err)
+				recordShardTxFailed(ctx.Context(), namespace)
 				return resPreBlock, eris.Wrapf(err, "failed to process game shard tx data submission")
 			}
+			namespaceCounts[namespace]++
+			emitShardTxSubmittedEvent(ctx, namespace, txHash, tick, namespaceCounts[namespace])
+			recordShardTxSubmitted(ctx.Context(), namespace, time.Since(start))
 		}
 		app.Logger().Info(fmt.Sprintf("Successfully processed %d game shard tx submissions", numShardTxDataMsgs))
 	}
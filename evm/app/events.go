@@ -0,0 +1,76 @@
+package app
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rotisserie/eris"
+
+	shardtypes "pkg.world.dev/world-engine/evm/x/shard/types"
+)
+
+// Event types and attribute keys emitted by preBlocker so downstream Cosmos indexers, block
+// explorers, and the streaming services registered by RegisterStreamingServices can observe shard
+// sequencing outcomes without scraping logs.
+const (
+	EventTypeShardRegistered  = "shard_registered"
+	EventTypeShardTxSubmitted = "shard_tx_submitted"
+	EventTypeShardTxFailed    = "shard_tx_failed"
+
+	AttributeKeyNamespace = "namespace"
+	AttributeKeyAddress   = "address"
+	AttributeKeyTxHash    = "tx_hash"
+	AttributeKeyTick      = "tick"
+	AttributeKeyCount     = "count"
+	AttributeKeyError     = "error"
+)
+
+// shardTxIdentity pulls the namespace and tx hash off a shard tx data message, which
+// App.preBlocker needs for event emission and per-namespace telemetry. It type-asserts to the
+// concrete *shardtypes.MsgSubmitShardTx rather than an optional duck-typed getter: every message
+// ShardSequencer.FlushMessages hands to preBlocker's shard-tx-data batch is a MsgSubmitShardTx, so
+// a mismatch here means the batch itself is wrong and must surface as an error, not silently
+// resolve to an empty namespace/tx_hash. MsgSubmitShardTx has a Namespace field but no TxHash
+// accessor, so the hash identifying the event is derived from the payload itself.
+func shardTxIdentity(msg sdk.Msg) (namespace, txHash string, err error) {
+	submitMsg, ok := msg.(*shardtypes.MsgSubmitShardTx)
+	if !ok {
+		return "", "", eris.Errorf("expected *shardtypes.MsgSubmitShardTx in shard tx data batch, got %T", msg)
+	}
+	hash := sha256.Sum256(submitMsg.TxData)
+	return submitMsg.Namespace, hex.EncodeToString(hash[:]), nil
+}
+
+func emitShardRegisteredEvent(ctx sdk.Context, namespace, address string) {
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			EventTypeShardRegistered,
+			sdk.NewAttribute(AttributeKeyNamespace, namespace),
+			sdk.NewAttribute(AttributeKeyAddress, address),
+		),
+	)
+}
+
+func emitShardTxSubmittedEvent(ctx sdk.Context, namespace, txHash string, tick uint64, count int) {
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			EventTypeShardTxSubmitted,
+			sdk.NewAttribute(AttributeKeyNamespace, namespace),
+			sdk.NewAttribute(AttributeKeyTxHash, txHash),
+			sdk.NewAttribute(AttributeKeyTick, strconv.FormatUint(tick, 10)),
+			sdk.NewAttribute(AttributeKeyCount, strconv.Itoa(count)),
+		),
+	)
+}
+
+func emitShardTxFailedEvent(ctx sdk.Context, namespace string, cause error) {
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			EventTypeShardTxFailed,
+			sdk.NewAttribute(AttributeKeyNamespace, namespace),
+			sdk.NewAttribute(AttributeKeyError, cause.Error()),
+		),
+	)
+}
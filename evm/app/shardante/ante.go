@@ -0,0 +1,188 @@
+// Package shardante provides a dedicated ante path for the World Engine shard tx message family
+// (MsgRegisterShard, MsgSubmitShardTx). It mirrors the Ethermint-style pattern already used for
+// EVM txs in cosmos/runtime/ante: a small set of decorators scoped to one tx family, chained in
+// front of the generic auth ante handler, so malformed or forged shard traffic is rejected in
+// CheckTx rather than surfacing as a preBlocker error after it has already been accepted into a
+// block.
+package shardante
+
+import (
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/rotisserie/eris"
+
+	shardtypes "pkg.world.dev/world-engine/evm/x/shard/types"
+)
+
+const (
+	// maxShardTxPayloadBytes bounds MsgSubmitShardTx.TxData so a single oversized payload can't
+	// blow up the batch decoded and replayed by App.preBlocker.
+	maxShardTxPayloadBytes = 1 << 20 // 1 MiB
+
+	// defaultNamespaceRateLimit bounds how many MsgSubmitShardTx a single namespace may submit per
+	// block, so a misbehaving or compromised sequencer can't balloon preBlocker's per-block work.
+	defaultNamespaceRateLimit = 500
+)
+
+// NamespaceSequencerKeeper is the subset of namespacekeeper.Keeper the ante decorators need:
+// resolving which account is authorized to submit transactions on behalf of a namespace.
+//
+// *namespacekeeper.Keeper must satisfy this interface for App.NewApp's
+// shardante.NewAnteHandler(app.NamespaceKeeper, ...) call to compile; if the real keeper exposes
+// the namespace-to-sequencer-address mapping under a different name, add a
+// GetSequencerAddress(ctx sdk.Context, shardName string) (string, bool) method to it (or a thin
+// wrapper calling through to whatever it's actually named) rather than changing this interface,
+// since this is also the interface the tests in this package fake.
+type NamespaceSequencerKeeper interface {
+	GetSequencerAddress(ctx sdk.Context, shardName string) (string, bool)
+}
+
+// ShardAuthDecorator verifies that MsgSubmitShardTx messages are signed by the account registered
+// as that namespace's sequencer. MsgRegisterShard is deliberately not gated the same way: a
+// namespace has no registered sequencer until its MsgRegisterShard is processed, so requiring one
+// up front would make every namespace's first registration unauthorizable.
+type ShardAuthDecorator struct {
+	namespaceKeeper NamespaceSequencerKeeper
+}
+
+func NewShardAuthDecorator(namespaceKeeper NamespaceSequencerKeeper) ShardAuthDecorator {
+	return ShardAuthDecorator{namespaceKeeper: namespaceKeeper}
+}
+
+func (d ShardAuthDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, error) {
+	for _, msg := range tx.GetMsgs() {
+		submitMsg, ok := msg.(*shardtypes.MsgSubmitShardTx)
+		if !ok {
+			continue
+		}
+		sequencer, registered := d.namespaceKeeper.GetSequencerAddress(ctx, submitMsg.Namespace)
+		if !registered || sequencer != submitMsg.Sender {
+			return ctx, eris.Wrapf(
+				sdkerrors.ErrUnauthorized,
+				"account %q is not the registered sequencer for namespace %q", submitMsg.Sender, submitMsg.Namespace,
+			)
+		}
+	}
+	return next(ctx, tx, simulate)
+}
+
+// RateLimitDecorator bounds how many MsgSubmitShardTx a single namespace may submit per mempool
+// admission window, so one namespace can't balloon the work App.preBlocker has to replay.
+//
+// It only counts against first-pass CheckTx (ctx.IsCheckTx() && !ctx.IsReCheckTx() && !simulate):
+// DeliverTx re-executes every tx that CheckTx already admitted, so counting there too would reject
+// or double-count the same tx against the same limit, and gas-estimation simulations never reach
+// the mempool at all. Restricting to that one phase is also what makes a single in-memory map
+// keyed on block height safe — CheckTx and DeliverTx no longer share it, so a DeliverTx at height H
+// can no longer thrash or exhaust the window a concurrent CheckTx at height H is counting against.
+type RateLimitDecorator struct {
+	mu          *sync.Mutex
+	limit       int
+	windowStart int64
+	counts      map[string]int
+}
+
+func NewRateLimitDecorator(limit int) *RateLimitDecorator {
+	if limit <= 0 {
+		limit = defaultNamespaceRateLimit
+	}
+	return &RateLimitDecorator{mu: &sync.Mutex{}, limit: limit, counts: make(map[string]int)}
+}
+
+func (d *RateLimitDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, error) {
+	if simulate || !ctx.IsCheckTx() || ctx.IsReCheckTx() {
+		return next(ctx, tx, simulate)
+	}
+	for _, msg := range tx.GetMsgs() {
+		submitMsg, ok := msg.(*shardtypes.MsgSubmitShardTx)
+		if !ok {
+			continue
+		}
+		if err := d.checkAndIncr(ctx.BlockHeight(), submitMsg.Namespace); err != nil {
+			return ctx, err
+		}
+	}
+	return next(ctx, tx, simulate)
+}
+
+func (d *RateLimitDecorator) checkAndIncr(blockHeight int64, namespace string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if blockHeight != d.windowStart {
+		d.windowStart = blockHeight
+		d.counts = make(map[string]int)
+	}
+	d.counts[namespace]++
+	if d.counts[namespace] > d.limit {
+		return eris.Wrapf(
+			sdkerrors.ErrInvalidRequest,
+			"namespace %q exceeded shard tx rate limit of %d per block", namespace, d.limit,
+		)
+	}
+	return nil
+}
+
+// PayloadSanityDecorator rejects MsgSubmitShardTx whose payload is empty or larger than
+// maxShardTxPayloadBytes, before the batch reaches App.preBlocker. The payload is not decoded, so
+// its encoding is not checked.
+type PayloadSanityDecorator struct{}
+
+func NewPayloadSanityDecorator() PayloadSanityDecorator {
+	return PayloadSanityDecorator{}
+}
+
+func (PayloadSanityDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, error) {
+	for _, msg := range tx.GetMsgs() {
+		submitMsg, ok := msg.(*shardtypes.MsgSubmitShardTx)
+		if !ok {
+			continue
+		}
+		if len(submitMsg.TxData) == 0 {
+			return ctx, eris.Wrap(sdkerrors.ErrInvalidRequest, "shard tx payload must not be empty")
+		}
+		if len(submitMsg.TxData) > maxShardTxPayloadBytes {
+			return ctx, eris.Wrapf(
+				sdkerrors.ErrInvalidRequest,
+				"shard tx payload of %d bytes exceeds the %d byte limit", len(submitMsg.TxData), maxShardTxPayloadBytes,
+			)
+		}
+	}
+	return next(ctx, tx, simulate)
+}
+
+// NewAnteHandler chains the shard-specific decorators in front of next, so forged or malformed
+// shard traffic is rejected in CheckTx before next (typically the generic auth ante handler) and
+// App.preBlocker ever see it. A rateLimit <= 0 falls back to defaultNamespaceRateLimit.
+func NewAnteHandler(namespaceKeeper NamespaceSequencerKeeper, rateLimit int, next sdk.AnteHandler) sdk.AnteHandler {
+	authDecorator := NewShardAuthDecorator(namespaceKeeper)
+	rateLimitDecorator := NewRateLimitDecorator(rateLimit)
+	payloadDecorator := NewPayloadSanityDecorator()
+
+	return func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		ctx, err := authDecorator.AnteHandle(ctx, tx, simulate, terminate)
+		if err != nil {
+			return ctx, err
+		}
+		ctx, err = rateLimitDecorator.AnteHandle(ctx, tx, simulate, terminate)
+		if err != nil {
+			return ctx, err
+		}
+		ctx, err = payloadDecorator.AnteHandle(ctx, tx, simulate, terminate)
+		if err != nil {
+			return ctx, err
+		}
+		return next(ctx, tx, simulate)
+	}
+}
+
+func terminate(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) {
+	return ctx, nil
+}
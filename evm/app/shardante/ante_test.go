@@ -0,0 +1,115 @@
+package shardante_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"pkg.world.dev/world-engine/evm/app/shardante"
+	shardtypes "pkg.world.dev/world-engine/evm/x/shard/types"
+)
+
+type fakeNamespaceKeeper map[string]string
+
+func (f fakeNamespaceKeeper) GetSequencerAddress(_ sdk.Context, shardName string) (string, bool) {
+	addr, ok := f[shardName]
+	return addr, ok
+}
+
+func noopNext(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) {
+	return ctx, nil
+}
+
+type fakeTx struct {
+	msgs []sdk.Msg
+}
+
+func (t fakeTx) GetMsgs() []sdk.Msg { return t.msgs }
+
+func TestShardAuthDecorator_RejectsUnauthorizedSequencer(t *testing.T) {
+	keeper := fakeNamespaceKeeper{"game-shard": "cosmos1registeredsequencer"}
+	decorator := shardante.NewShardAuthDecorator(keeper)
+
+	forgedTx := fakeTx{msgs: []sdk.Msg{
+		&shardtypes.MsgSubmitShardTx{Namespace: "game-shard", Sender: "cosmos1attacker", TxData: []byte("payload")},
+	}}
+
+	_, err := decorator.AnteHandle(sdk.Context{}, forgedTx, false, noopNext)
+	require.Error(t, err)
+}
+
+func TestShardAuthDecorator_AllowsUnregisteredShardRegistration(t *testing.T) {
+	keeper := fakeNamespaceKeeper{}
+	decorator := shardante.NewShardAuthDecorator(keeper)
+
+	registerTx := fakeTx{msgs: []sdk.Msg{
+		&shardtypes.MsgRegisterShard{
+			Namespace: shardtypes.Namespace{ShardName: "new-shard", ShardAddress: "cosmos1newsequencer"},
+			Sender:    "cosmos1newsequencer",
+		},
+	}}
+
+	_, err := decorator.AnteHandle(sdk.Context{}, registerTx, false, noopNext)
+	require.NoError(t, err, "a namespace's first registration must not require an already-registered sequencer")
+}
+
+func TestShardAuthDecorator_AllowsRegisteredSequencer(t *testing.T) {
+	keeper := fakeNamespaceKeeper{"game-shard": "cosmos1registeredsequencer"}
+	decorator := shardante.NewShardAuthDecorator(keeper)
+
+	legitTx := fakeTx{msgs: []sdk.Msg{
+		&shardtypes.MsgSubmitShardTx{Namespace: "game-shard", Sender: "cosmos1registeredsequencer", TxData: []byte("payload")},
+	}}
+
+	_, err := decorator.AnteHandle(sdk.Context{}, legitTx, false, noopNext)
+	require.NoError(t, err)
+}
+
+func TestPayloadSanityDecorator_RejectsEmptyPayload(t *testing.T) {
+	decorator := shardante.NewPayloadSanityDecorator()
+
+	tx := fakeTx{msgs: []sdk.Msg{
+		&shardtypes.MsgSubmitShardTx{Namespace: "game-shard", Sender: "cosmos1registeredsequencer"},
+	}}
+
+	_, err := decorator.AnteHandle(sdk.Context{}, tx, false, noopNext)
+	require.Error(t, err)
+}
+
+func TestRateLimitDecorator_RejectsOverLimitSubmissions(t *testing.T) {
+	decorator := shardante.NewRateLimitDecorator(1)
+	ctx := sdk.Context{}.WithBlockHeight(10).WithIsCheckTx(true)
+
+	tx := fakeTx{msgs: []sdk.Msg{
+		&shardtypes.MsgSubmitShardTx{Namespace: "game-shard", Sender: "cosmos1registeredsequencer", TxData: []byte("payload")},
+	}}
+
+	_, err := decorator.AnteHandle(ctx, tx, false, noopNext)
+	require.NoError(t, err)
+
+	_, err = decorator.AnteHandle(ctx, tx, false, noopNext)
+	require.Error(t, err)
+}
+
+func TestRateLimitDecorator_DoesNotCountDeliverTxOrSimulate(t *testing.T) {
+	decorator := shardante.NewRateLimitDecorator(1)
+	checkCtx := sdk.Context{}.WithBlockHeight(10).WithIsCheckTx(true)
+	deliverCtx := sdk.Context{}.WithBlockHeight(10)
+
+	tx := fakeTx{msgs: []sdk.Msg{
+		&shardtypes.MsgSubmitShardTx{Namespace: "game-shard", Sender: "cosmos1registeredsequencer", TxData: []byte("payload")},
+	}}
+
+	// Gas-estimation simulation must never count against the limit.
+	_, err := decorator.AnteHandle(checkCtx, tx, true, noopNext)
+	require.NoError(t, err)
+
+	// CheckTx admits the tx once, consuming the limit of 1.
+	_, err = decorator.AnteHandle(checkCtx, tx, false, noopNext)
+	require.NoError(t, err)
+
+	// DeliverTx re-executing the same admitted tx must not be rejected by the CheckTx-scoped limit.
+	_, err = decorator.AnteHandle(deliverCtx, tx, false, noopNext)
+	require.NoError(t, err)
+}
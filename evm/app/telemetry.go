@@ -0,0 +1,39 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Shard sequencing metrics, recorded through the same OpenTelemetry/Datadog stack gamestate uses
+// for tracing, so operators can alert on per-namespace backlogs alongside existing dashboards.
+var (
+	shardMeter = otel.Meter("pkg.world.dev/world-engine/evm/app")
+
+	shardTxSubmittedCounter, _ = shardMeter.Int64Counter(
+		"shard_tx_submitted_total",
+		metric.WithDescription("Shard transactions successfully sequenced into a block, by namespace."),
+	)
+	shardTxFailedCounter, _ = shardMeter.Int64Counter(
+		"shard_tx_failed_total",
+		metric.WithDescription("Shard registrations or tx batches that failed to sequence, by namespace."),
+	)
+	shardTxLatencyHistogram, _ = shardMeter.Float64Histogram(
+		"shard_tx_sequencing_latency_ms",
+		metric.WithDescription("Time spent processing a single shard tx in preBlocker, by namespace."),
+	)
+)
+
+func recordShardTxSubmitted(ctx context.Context, namespace string, latency time.Duration) {
+	attrs := metric.WithAttributes(attribute.String(AttributeKeyNamespace, namespace))
+	shardTxSubmittedCounter.Add(ctx, 1, attrs)
+	shardTxLatencyHistogram.Record(ctx, float64(latency.Milliseconds()), attrs)
+}
+
+func recordShardTxFailed(ctx context.Context, namespace string) {
+	shardTxFailedCounter.Add(ctx, 1, metric.WithAttributes(attribute.String(AttributeKeyNamespace, namespace)))
+}